@@ -0,0 +1,212 @@
+// Command server runs the automation controller's HTTP API.
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"net/http"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+
+	"github.com/your-org/project-name/internal/config"
+	"github.com/your-org/project-name/pkg/audit"
+	"github.com/your-org/project-name/pkg/cache"
+	"github.com/your-org/project-name/pkg/eventstore"
+	"github.com/your-org/project-name/pkg/identity"
+	"github.com/your-org/project-name/pkg/observability"
+	"github.com/your-org/project-name/pkg/orchestrator"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := config.FromEnv()
+	if err != nil {
+		log.Fatalf("config: %v", err)
+	}
+
+	providers, err := observability.NewProviders(ctx, observability.ConfigFromEnv())
+	if err != nil {
+		log.Fatalf("observability: %v", err)
+	}
+	defer func() {
+		if err := providers.Shutdown(context.Background()); err != nil {
+			log.Printf("observability: shutdown: %v", err)
+		}
+	}()
+
+	router := newRouter()
+
+	if providers.Registry != nil {
+		// Only reached when OTLPEndpoint isn't configured and metrics
+		// fell back to the in-process Prometheus exporter; otherwise
+		// they're pushed to the collector instead. EnableOpenMetrics
+		// lets the response carry the exemplars the exporter attaches.
+		if err := cache.RegisterMetrics(providers.Registry); err != nil {
+			log.Fatalf("cache: register metrics: %v", err)
+		}
+		router.Handle("/metrics", promhttp.HandlerFor(providers.Registry, promhttp.HandlerOpts{
+			EnableOpenMetrics: true,
+		})).Methods(http.MethodGet)
+	}
+
+	if cfg.EventStore.Enabled {
+		sink, err := eventstore.NewClickHouseSink(ctx, eventstore.ClickHouseConfig{
+			Addr:     cfg.EventStore.Addr,
+			Database: cfg.EventStore.Database,
+		})
+		if err != nil {
+			log.Fatalf("eventstore: %v", err)
+		}
+		defer func() {
+			if err := sink.Close(context.Background()); err != nil {
+				log.Printf("eventstore: close: %v", err)
+			}
+		}()
+
+		router.Handle("/events", eventstore.Handler(sink)).Methods(http.MethodGet)
+	}
+
+	if cfg.Audit.Enabled {
+		key, err := audit.LoadOrGenerateKey(cfg.Audit.SigningKeyPath)
+		if err != nil {
+			log.Fatalf("audit: %v", err)
+		}
+		signer, err := audit.NewSigner(key, "automation-controller")
+		if err != nil {
+			log.Fatalf("audit: %v", err)
+		}
+
+		auditLog := audit.NewLog(signer)
+		checkpoints := audit.NewCheckpointSigner(ctx, auditLog, signer, cfg.Audit.CheckpointInterval)
+
+		var uploader *audit.RekorUploader
+		if cfg.Audit.RekorURL != "" {
+			uploader = audit.NewRekorUploader(cfg.Audit.RekorURL)
+		}
+
+		router.Use(audit.Middleware(auditLog, signer, uploader))
+		router.Handle("/audit/{id}/proof", audit.ProofHandler(checkpoints)).Methods(http.MethodGet)
+	}
+
+	if _, err := orchestrator.NewBackend(orchestrator.BackendConfig{
+		Kind:      cfg.Orchestrator.Backend,
+		NomadAddr: cfg.Orchestrator.NomadAddr,
+	}); err != nil {
+		log.Fatalf("orchestrator: %v", err)
+	}
+
+	if cfg.Orchestrator.ConsulAddr != "" {
+		consulClient, err := orchestrator.NewConsulClient(cfg.Orchestrator.ConsulAddr)
+		if err != nil {
+			log.Fatalf("orchestrator: consul client: %v", err)
+		}
+
+		host, portStr, err := net.SplitHostPort(cfg.ListenAddr)
+		if err != nil {
+			log.Fatalf("orchestrator: parse listen addr: %v", err)
+		}
+		if host == "" {
+			host = "127.0.0.1"
+		}
+		port, err := strconv.Atoi(portStr)
+		if err != nil {
+			log.Fatalf("orchestrator: parse listen port: %v", err)
+		}
+
+		serviceID := cfg.Orchestrator.ServiceName
+		if err := orchestrator.RegisterService(consulClient, serviceID, cfg.Orchestrator.ServiceName, host, port); err != nil {
+			log.Fatalf("orchestrator: register consul service: %v", err)
+		}
+		defer func() {
+			if err := orchestrator.DeregisterService(consulClient, serviceID); err != nil {
+				log.Printf("orchestrator: deregister consul service: %v", err)
+			}
+		}()
+
+		watcher := orchestrator.NewKVWatcher(consulClient, "automation/config/")
+		go func() {
+			if err := watcher.Watch(ctx, func(values map[string]string) {
+				log.Printf("orchestrator: config updated from consul KV: %d keys", len(values))
+			}); err != nil && ctx.Err() == nil {
+				log.Printf("orchestrator: consul KV watch stopped: %v", err)
+			}
+		}()
+	}
+
+	if err := run(ctx, cfg, router); err != nil {
+		log.Fatalf("server: %v", err)
+	}
+}
+
+func newRouter() *mux.Router {
+	r := mux.NewRouter()
+	r.Use(observability.TracingMiddleware, observability.MetricsMiddleware)
+	r.HandleFunc("/healthz", healthzHandler).Methods(http.MethodGet)
+	r.Handle("/internal/cache/{name}", cache.AdminHandler()).Methods(http.MethodDelete)
+	return r
+}
+
+func healthzHandler(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+func run(ctx context.Context, cfg config.Config, router *mux.Router) error {
+	if !cfg.Identity.Enabled {
+		srv := &http.Server{Addr: cfg.ListenAddr, Handler: router}
+		return serve(ctx, srv)
+	}
+
+	src := identity.NewSource(cfg.Identity.AgentSocket)
+	defer src.Close()
+
+	matcher, err := identity.AllowedMatcher(cfg.Identity.TrustDomain, cfg.Identity.AllowedIDs)
+	if err != nil {
+		return err
+	}
+
+	authzCache, err := identity.NewAuthzCache()
+	if err != nil {
+		return err
+	}
+	matcher = identity.CachedMatcher(matcher, authzCache)
+
+	srv, err := identity.NewServer(ctx, cfg.ListenAddr, src, tlsconfig.AdaptMatcher(matcher), router)
+	if err != nil {
+		return err
+	}
+
+	return serveTLS(ctx, srv)
+}
+
+func serve(ctx context.Context, srv *http.Server) error {
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.ListenAndServe() }()
+
+	select {
+	case <-ctx.Done():
+		return srv.Shutdown(context.Background())
+	case err := <-errCh:
+		return err
+	}
+}
+
+func serveTLS(ctx context.Context, srv *http.Server) error {
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.ListenAndServeTLS("", "") }()
+
+	select {
+	case <-ctx.Done():
+		return srv.Shutdown(context.Background())
+	case err := <-errCh:
+		return err
+	}
+}
@@ -0,0 +1,150 @@
+// Package config centralizes environment-driven configuration for the
+// automation controller and its subsystems.
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Config holds the settings needed to start the automation controller.
+// Subsystems add their own nested structs here as they're wired in,
+// keeping a single source of truth for what can be tuned from the
+// environment.
+type Config struct {
+	// ListenAddr is the address the HTTP server binds to.
+	ListenAddr string
+
+	Identity     IdentityConfig
+	EventStore   EventStoreConfig
+	Audit        AuditConfig
+	Orchestrator OrchestratorConfig
+}
+
+// IdentityConfig controls how the controller authenticates its HTTP
+// server and outbound clients via SPIFFE/SPIRE.
+type IdentityConfig struct {
+	// Enabled turns on SPIFFE-based mTLS. When false, the server listens
+	// over plain HTTP without TLS.
+	Enabled bool
+
+	// AgentSocket is the path to the SPIRE Workload API UDS, e.g.
+	// "unix:///run/spire/sockets/agent.sock".
+	AgentSocket string
+
+	// TrustDomain is the SPIFFE trust domain this controller belongs to,
+	// e.g. "automation.example.com".
+	TrustDomain string
+
+	// AllowedIDs is a set of SPIFFE ID match patterns (exact IDs,
+	// "spiffe://domain/path/*" prefixes, or trust domains) that are
+	// permitted to call into this controller.
+	AllowedIDs []string
+}
+
+// EventStoreConfig controls the ClickHouse sink used for automation
+// run event history.
+type EventStoreConfig struct {
+	// Enabled turns on event recording. When false, callers should use
+	// a no-op sink.
+	Enabled bool
+
+	// Addr is the ClickHouse native protocol address, e.g. "localhost:9000".
+	Addr     string
+	Database string
+}
+
+// AuditConfig controls the tamper-evident audit log for mutating API
+// calls.
+type AuditConfig struct {
+	// Enabled turns on audit recording of mutating requests.
+	Enabled bool
+
+	// CheckpointInterval is how often the log's root hash is signed
+	// into a new checkpoint.
+	CheckpointInterval time.Duration
+
+	// RekorURL, if set, mirrors signed entries to an external Rekor
+	// instance in addition to the local Merkle log.
+	RekorURL string
+
+	// SigningKeyPath is a PEM-encoded PKCS#8 ed25519 private key used to
+	// sign statements and checkpoints. If empty, an ephemeral key is
+	// generated for the process lifetime.
+	SigningKeyPath string
+}
+
+// OrchestratorConfig selects how automation tasks are dispatched.
+type OrchestratorConfig struct {
+	// Backend is "exec" (default) or "nomad".
+	Backend string
+
+	NomadAddr  string
+	ConsulAddr string
+
+	// ServiceName is what the controller registers itself as in Consul
+	// when ConsulAddr is set.
+	ServiceName string
+}
+
+// FromEnv builds a Config from environment variables, applying defaults
+// for anything unset.
+func FromEnv() (Config, error) {
+	cfg := Config{
+		ListenAddr: getEnv("LISTEN_ADDR", ":8080"),
+		Identity: IdentityConfig{
+			Enabled:     getEnv("SPIFFE_ENABLED", "") == "true",
+			AgentSocket: getEnv("SPIFFE_AGENT_SOCKET", "unix:///run/spire/sockets/agent.sock"),
+			TrustDomain: getEnv("SPIFFE_TRUST_DOMAIN", ""),
+			AllowedIDs:  splitCSV(getEnv("SPIFFE_ALLOWED_IDS", "")),
+		},
+		EventStore: EventStoreConfig{
+			Enabled:  getEnv("CLICKHOUSE_ENABLED", "") == "true",
+			Addr:     getEnv("CLICKHOUSE_ADDR", "localhost:9000"),
+			Database: getEnv("CLICKHOUSE_DATABASE", "default"),
+		},
+		Audit: AuditConfig{
+			Enabled:            getEnv("AUDIT_ENABLED", "") == "true",
+			CheckpointInterval: 10 * time.Second,
+			RekorURL:           getEnv("AUDIT_REKOR_URL", ""),
+			SigningKeyPath:     getEnv("AUDIT_SIGNING_KEY_PATH", ""),
+		},
+		Orchestrator: OrchestratorConfig{
+			Backend:     getEnv("ORCHESTRATOR_BACKEND", "exec"),
+			NomadAddr:   getEnv("NOMAD_ADDR", ""),
+			ConsulAddr:  getEnv("CONSUL_ADDR", ""),
+			ServiceName: getEnv("CONSUL_SERVICE_NAME", "automation-controller"),
+		},
+	}
+
+	if cfg.Identity.Enabled && cfg.Identity.TrustDomain == "" {
+		return Config{}, fmt.Errorf("config: SPIFFE_TRUST_DOMAIN must be set when SPIFFE_ENABLED=true")
+	}
+
+	return cfg, nil
+}
+
+func getEnv(key, fallback string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return fallback
+}
+
+func splitCSV(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var out []string
+	start := 0
+	for i := 0; i <= len(s); i++ {
+		if i == len(s) || s[i] == ',' {
+			if i > start {
+				out = append(out, s[start:i])
+			}
+			start = i + 1
+		}
+	}
+	return out
+}
@@ -0,0 +1,44 @@
+package audit
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// proofResponse is the JSON body returned by GET /audit/{id}/proof.
+type proofResponse struct {
+	EntryID        string   `json:"entry_id"`
+	InclusionProof []string `json:"inclusion_proof"`
+	TreeSize       int64    `json:"tree_size"`
+	RootHash       string   `json:"root_hash"`
+	CheckpointSig  string   `json:"checkpoint_signature"`
+}
+
+// ProofHandler returns an http.HandlerFunc for GET /audit/{id}/proof,
+// serving the inclusion proof for entry id against the most recently
+// signed checkpoint.
+func ProofHandler(cs *CheckpointSigner) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := mux.Vars(r)["id"]
+
+		checkpoint, proof, err := cs.InclusionProof(id)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		resp := proofResponse{
+			EntryID:        id,
+			InclusionProof: proof,
+			TreeSize:       checkpoint.Size,
+			RootHash:       hex.EncodeToString(checkpoint.RootHash[:]),
+			CheckpointSig:  hex.EncodeToString(checkpoint.Signature),
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
@@ -0,0 +1,46 @@
+package audit
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+)
+
+// LoadOrGenerateKey reads a PEM-encoded PKCS#8 ed25519 private key from
+// path. If path is empty, it generates an ephemeral key for the
+// process lifetime — fine for development, but checkpoints signed this
+// way aren't verifiable across restarts, so production deployments
+// should always configure a persistent key.
+func LoadOrGenerateKey(path string) (crypto.Signer, error) {
+	if path == "" {
+		_, priv, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			return nil, fmt.Errorf("audit: generate ephemeral key: %w", err)
+		}
+		return priv, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("audit: read signing key %q: %w", path, err)
+	}
+
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("audit: %q is not PEM-encoded", path)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("audit: parse signing key %q: %w", path, err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("audit: %q does not hold a signing key", path)
+	}
+	return signer, nil
+}
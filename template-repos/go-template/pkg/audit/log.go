@@ -0,0 +1,95 @@
+// Package audit records every mutating API call into an append-only
+// Merkle-tree log, so operators can later prove that a given record
+// was included without trusting the server, and optionally mirrors
+// signed entries to an external Rekor transparency log for
+// supply-chain-grade evidence.
+package audit
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Entry is one append-only audit record: an in-toto statement (subject
+// = the resource that changed, predicate = the request payload and
+// actor) signed as a DSSE envelope.
+type Entry struct {
+	ID        string
+	Statement Statement
+	Envelope  DSSEEnvelope
+	Timestamp time.Time
+
+	leafHash [sha256.Size]byte
+}
+
+// Log is an in-memory, append-only Merkle tree of audit Entry leaves.
+// A CheckpointSigner signs its state periodically so a Signed Tree Head
+// can be handed out alongside inclusion proofs.
+type Log struct {
+	signer Signer
+
+	mu      sync.Mutex
+	entries []Entry
+	byID    map[string]int
+
+	checkpoint Checkpoint
+}
+
+// NewLog returns an empty Log. signer is stored on the Log but none of
+// its methods use it; checkpoints are signed by a CheckpointSigner.
+func NewLog(signer Signer) *Log {
+	return &Log{
+		signer: signer,
+		byID:   make(map[string]int),
+	}
+}
+
+// Append adds e to the log, computing its leaf hash, and returns the
+// entry's index (its position in the tree, needed for InclusionProof).
+func (l *Log) Append(e Entry) (int, error) {
+	leaf, err := hashEntry(e)
+	if err != nil {
+		return 0, fmt.Errorf("audit: hash entry: %w", err)
+	}
+	e.leafHash = leaf
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	idx := len(l.entries)
+	l.entries = append(l.entries, e)
+	l.byID[e.ID] = idx
+
+	return idx, nil
+}
+
+// Get returns the entry previously appended with the given ID.
+func (l *Log) Get(id string) (Entry, int, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	idx, ok := l.byID[id]
+	if !ok {
+		return Entry{}, 0, false
+	}
+	return l.entries[idx], idx, true
+}
+
+// Size returns the number of entries currently in the log.
+func (l *Log) Size() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.entries)
+}
+
+func hashEntry(e Entry) ([sha256.Size]byte, error) {
+	b, err := canonicalBytes(e.Envelope)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	// RFC 6962 leaf hash: a 0x00 prefix domain-separates leaves from
+	// internal nodes so a leaf can never be mistaken for one.
+	return sha256.Sum256(append([]byte{0x00}, b...)), nil
+}
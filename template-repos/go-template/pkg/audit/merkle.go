@@ -0,0 +1,56 @@
+package audit
+
+import "crypto/sha256"
+
+// rootHash computes the RFC 6962 Merkle tree hash over leaves,
+// recursively splitting at the largest power of two strictly smaller
+// than len(leaves) as the reference algorithm does.
+func rootHash(leaves [][sha256.Size]byte) [sha256.Size]byte {
+	switch len(leaves) {
+	case 0:
+		return sha256.Sum256(nil) // hash of the empty string, per RFC 6962
+	case 1:
+		return leaves[0]
+	default:
+		k := largestPowerOfTwoLessThan(len(leaves))
+		left := rootHash(leaves[:k])
+		right := rootHash(leaves[k:])
+		return nodeHash(left, right)
+	}
+}
+
+func nodeHash(left, right [sha256.Size]byte) [sha256.Size]byte {
+	buf := make([]byte, 0, 1+2*sha256.Size)
+	buf = append(buf, 0x01) // domain separation from leaf hashes
+	buf = append(buf, left[:]...)
+	buf = append(buf, right[:]...)
+	return sha256.Sum256(buf)
+}
+
+// inclusionProof returns the audit path proving that leaves[index] is
+// included in the tree over leaves, following the same recursive split
+// as rootHash.
+func inclusionProof(leaves [][sha256.Size]byte, index int) [][sha256.Size]byte {
+	if len(leaves) <= 1 {
+		return nil
+	}
+
+	k := largestPowerOfTwoLessThan(len(leaves))
+	if index < k {
+		proof := inclusionProof(leaves[:k], index)
+		sibling := rootHash(leaves[k:])
+		return append(proof, sibling)
+	}
+
+	proof := inclusionProof(leaves[k:], index-k)
+	sibling := rootHash(leaves[:k])
+	return append(proof, sibling)
+}
+
+func largestPowerOfTwoLessThan(n int) int {
+	k := 1
+	for k*2 < n {
+		k *= 2
+	}
+	return k
+}
@@ -0,0 +1,46 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInclusionProof_VerifiesAgainstRoot(t *testing.T) {
+	leaves := make([][sha256.Size]byte, 7)
+	for i := range leaves {
+		leaves[i] = sha256.Sum256([]byte{byte(i)})
+	}
+
+	want := rootHash(leaves)
+
+	for i := range leaves {
+		proof := inclusionProof(leaves, i)
+		got := recomputeRoot(leaves[i], i, len(leaves), proof)
+		require.Equal(t, want, got, "leaf %d", i)
+	}
+}
+
+// recomputeRoot folds an audit path into a root hash the same way a
+// verifier would, without access to the rest of the tree.
+func recomputeRoot(leaf [sha256.Size]byte, index, size int, proof [][sha256.Size]byte) [sha256.Size]byte {
+	return recomputeSubtree(leaf, index, size, proof)
+}
+
+func recomputeSubtree(leaf [sha256.Size]byte, index, size int, proof [][sha256.Size]byte) [sha256.Size]byte {
+	if size <= 1 {
+		return leaf
+	}
+
+	k := largestPowerOfTwoLessThan(size)
+	sibling := proof[len(proof)-1]
+	rest := proof[:len(proof)-1]
+
+	if index < k {
+		left := recomputeSubtree(leaf, index, k, rest)
+		return nodeHash(left, sibling)
+	}
+	right := recomputeSubtree(leaf, index-k, size-k, rest)
+	return nodeHash(sibling, right)
+}
@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/your-org/project-name/pkg/identity"
+)
+
+var mutatingMethods = map[string]bool{
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+// Middleware records every mutating request (POST/PUT/PATCH/DELETE)
+// into log as a signed audit Entry after next has handled it. The
+// recording (signing, appending, and the Rekor upload via uploader if
+// one is configured) runs in its own goroutine, so it doesn't block the
+// response.
+func Middleware(log *Log, signer Signer, uploader *RekorUploader) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !mutatingMethods[r.Method] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, _ := io.ReadAll(r.Body)
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			next.ServeHTTP(w, r)
+
+			go recordEntry(context.Background(), log, signer, uploader, r, body)
+		})
+	}
+}
+
+func recordEntry(ctx context.Context, log *Log, signer Signer, uploader *RekorUploader, r *http.Request, body []byte) {
+	actor := ""
+	if id, ok := identity.PeerIDFromContext(r.Context()); ok {
+		actor = id.String()
+	}
+
+	resource := r.URL.Path
+	if route := mux.CurrentRoute(r); route != nil {
+		if tmpl, err := route.GetPathTemplate(); err == nil {
+			resource = tmpl
+		}
+	}
+
+	stmt, err := NewStatement(resource, actor, r.Method, r.URL.Path, body)
+	if err != nil {
+		return
+	}
+
+	envelope, err := signer.SignStatement(ctx, stmt)
+	if err != nil {
+		return
+	}
+
+	entry := Entry{
+		ID:        newEntryID(),
+		Statement: stmt,
+		Envelope:  envelope,
+		Timestamp: time.Now(),
+	}
+
+	if _, err := log.Append(entry); err != nil {
+		return
+	}
+
+	if uploader != nil {
+		_, _ = uploader.Upload(ctx, entry)
+	}
+}
+
+func newEntryID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
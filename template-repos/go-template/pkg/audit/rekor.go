@@ -0,0 +1,64 @@
+package audit
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/sigstore/rekor/pkg/client"
+	"github.com/sigstore/rekor/pkg/generated/client/entries"
+	"github.com/sigstore/rekor/pkg/generated/models"
+)
+
+// RekorUploader submits signed audit entries to an external Rekor
+// instance, giving the automation infra an independently-hosted
+// transparency record in addition to the local Merkle log. It's
+// optional: if no Rekor URL is configured, callers simply don't
+// construct one.
+type RekorUploader struct {
+	rekorURL string
+}
+
+// NewRekorUploader targets the Rekor instance at rekorURL (e.g.
+// "https://rekor.sigstore.dev").
+func NewRekorUploader(rekorURL string) *RekorUploader {
+	return &RekorUploader{rekorURL: rekorURL}
+}
+
+// Upload submits e's DSSE envelope as a dsse-typed Rekor entry and
+// returns the log entry UUID.
+func (u *RekorUploader) Upload(ctx context.Context, e Entry) (string, error) {
+	rekorClient, err := client.GetRekorClient(u.rekorURL)
+	if err != nil {
+		return "", fmt.Errorf("audit: build rekor client: %w", err)
+	}
+
+	envelopeBytes, err := canonicalBytes(e.Envelope)
+	if err != nil {
+		return "", fmt.Errorf("audit: encode envelope: %w", err)
+	}
+
+	proposed := models.Dsse{
+		APIVersion: stringPtr("0.0.1"),
+		Spec: map[string]interface{}{
+			"proposedContent": map[string]interface{}{
+				"envelope": base64.StdEncoding.EncodeToString(envelopeBytes),
+			},
+		},
+	}
+
+	params := entries.NewCreateLogEntryParamsWithContext(ctx)
+	params.SetProposedEntry(&proposed)
+
+	resp, err := rekorClient.Entries.CreateLogEntry(params)
+	if err != nil {
+		return "", fmt.Errorf("audit: submit to rekor: %w", err)
+	}
+
+	for uuid := range resp.Payload {
+		return uuid, nil
+	}
+	return "", fmt.Errorf("audit: rekor returned no entry UUID")
+}
+
+func stringPtr(s string) *string { return &s }
@@ -0,0 +1,77 @@
+package audit
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+
+	dsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+// Signer produces a DSSE envelope for a statement and signs checkpoint
+// root hashes. It's satisfied by a cosign-style crypto.Signer wrapped
+// in a dsse.EnvelopeSigner, or a test double.
+type Signer interface {
+	SignStatement(ctx context.Context, stmt Statement) (DSSEEnvelope, error)
+	SignCheckpoint(ctx context.Context, rootHash []byte, size int64) ([]byte, error)
+}
+
+// cryptoSigner adapts a crypto.Signer (e.g. one loaded from a KMS or a
+// local ed25519 key) to the Signer interface.
+type cryptoSigner struct {
+	key    crypto.Signer
+	keyID  string
+	envSig *dsse.EnvelopeSigner
+}
+
+// NewSigner wraps key (and its key ID, used as the DSSE key ID hint)
+// for signing audit statements and checkpoints.
+func NewSigner(key crypto.Signer, keyID string) (Signer, error) {
+	envSig, err := dsse.NewEnvelopeSigner(&dsseAdapter{key: key, keyID: keyID})
+	if err != nil {
+		return nil, fmt.Errorf("audit: build DSSE signer: %w", err)
+	}
+
+	return &cryptoSigner{key: key, keyID: keyID, envSig: envSig}, nil
+}
+
+func (s *cryptoSigner) SignStatement(ctx context.Context, stmt Statement) (DSSEEnvelope, error) {
+	b, err := json.Marshal(stmt)
+	if err != nil {
+		return DSSEEnvelope{}, fmt.Errorf("audit: marshal statement: %w", err)
+	}
+
+	env, err := s.envSig.SignPayload(ctx, inTotoPayloadType, b)
+	if err != nil {
+		return DSSEEnvelope{}, fmt.Errorf("audit: sign statement: %w", err)
+	}
+
+	return *env, nil
+}
+
+func (s *cryptoSigner) SignCheckpoint(ctx context.Context, rootHash []byte, size int64) ([]byte, error) {
+	note := checkpointNote(rootHash, size)
+	sig, err := s.key.Sign(rand.Reader, note, crypto.Hash(0))
+	if err != nil {
+		return nil, fmt.Errorf("audit: sign checkpoint: %w", err)
+	}
+	return sig, nil
+}
+
+const inTotoPayloadType = "application/vnd.in-toto+json"
+
+// dsseAdapter bridges crypto.Signer to dsse.Signer.
+type dsseAdapter struct {
+	key   crypto.Signer
+	keyID string
+}
+
+func (a *dsseAdapter) Sign(ctx context.Context, data []byte) ([]byte, error) {
+	return a.key.Sign(rand.Reader, data, crypto.Hash(0))
+}
+
+func (a *dsseAdapter) KeyID() (string, error) {
+	return a.keyID, nil
+}
@@ -0,0 +1,73 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/in-toto/in-toto-golang/in_toto"
+	dsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+const predicateType = "https://automation-infra/audit/v1"
+
+// Statement is the in-toto statement recorded for a single mutating
+// API call: subject identifies the resource that changed, and the
+// predicate carries the request payload plus the caller's identity.
+type Statement = in_toto.Statement
+
+// Predicate is the audit-specific predicate embedded in the statement.
+// Payload is the raw request body, base64-encoded by encoding/json's
+// standard []byte handling: request bodies aren't guaranteed to be
+// valid JSON (form-encoded, plain text, binary), and embedding them
+// as json.RawMessage would make json.Marshal fail on anything that
+// isn't, silently dropping the audit entry for that call.
+type Predicate struct {
+	Method  string `json:"method"`
+	Path    string `json:"path"`
+	Actor   string `json:"actor"` // SPIFFE ID of the caller, if known
+	Payload []byte `json:"payload,omitempty"`
+}
+
+// NewStatement builds the in-toto statement for a mutating call on
+// resource, recording actor, the identity layer's peer ID (or "" if the
+// request had none), in the predicate. The statement is not signed here.
+func NewStatement(resource, actor, method, path string, payload []byte) (Statement, error) {
+	predicate := Predicate{
+		Method:  method,
+		Path:    path,
+		Actor:   actor,
+		Payload: payload,
+	}
+
+	predicateMap := map[string]interface{}{}
+	b, err := json.Marshal(predicate)
+	if err != nil {
+		return Statement{}, fmt.Errorf("audit: marshal predicate: %w", err)
+	}
+	if err := json.Unmarshal(b, &predicateMap); err != nil {
+		return Statement{}, fmt.Errorf("audit: unmarshal predicate: %w", err)
+	}
+
+	return Statement{
+		StatementHeader: in_toto.StatementHeader{
+			Type:          in_toto.StatementInTotoV01,
+			PredicateType: predicateType,
+			Subject: []in_toto.Subject{
+				{Name: resource},
+			},
+		},
+		Predicate: predicateMap,
+	}, nil
+}
+
+// DSSEEnvelope is the cosign-style Dead Simple Signing Envelope wrapped
+// around a statement's canonical JSON bytes.
+type DSSEEnvelope = dsse.Envelope
+
+func canonicalBytes(env DSSEEnvelope) ([]byte, error) {
+	b, err := json.Marshal(env)
+	if err != nil {
+		return nil, fmt.Errorf("audit: marshal envelope: %w", err)
+	}
+	return b, nil
+}
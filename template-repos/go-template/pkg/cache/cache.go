@@ -0,0 +1,176 @@
+// Package cache wraps Ristretto as a generic, cost-aware cache for hot
+// mux-handler lookups (policy decisions, workflow manifests, parsed
+// templates), replacing the ad-hoc per-handler maps those paths used
+// before.
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"golang.org/x/sync/singleflight"
+)
+
+// Loader fetches the value for a cache miss. cost is the admission
+// cost charged against the cache's MaxCost; return 0 to let the cache
+// fall back to CostFunc (or a cost of 1 if neither is set).
+type Loader[K comparable, V any] func(ctx context.Context, key K) (value V, cost int64, err error)
+
+// Options configures a Cache.
+type Options[K comparable, V any] struct {
+	// Name identifies this cache in metrics and the admin invalidation
+	// handler.
+	Name string
+
+	// MaxCost bounds total admitted cost (Ristretto's NumCounters is
+	// derived from it; see New).
+	MaxCost int64
+
+	// TTL applied to every Set/loaded entry. Zero means entries never
+	// expire on their own.
+	TTL time.Duration
+
+	// CostFunc computes a value's cost when Set or a Loader doesn't
+	// supply one explicitly. Defaults to a flat cost of 1 (count-based
+	// admission) if nil.
+	CostFunc func(V) int64
+}
+
+// Cache is a generic, TTL- and cost-aware cache with singleflight
+// coalescing of concurrent loads for the same key.
+//
+// Ristretto/v2's own type parameter is constrained to z.Key (uint64,
+// string, []byte, byte, int32/64, uint32), not comparable, so K can't
+// be forwarded to it directly. Instead the wrapper keys the backing
+// ristretto.Cache on fmt.Sprint(key), which covers any comparable K
+// (strings, ints, structs with a useful String()/default formatting)
+// at the cost of an allocation per call.
+type Cache[K comparable, V any] struct {
+	name     string
+	ttl      time.Duration
+	costFunc func(V) int64
+
+	ristretto *ristretto.Cache[string, V]
+	group     singleflight.Group
+	metrics   *metrics
+}
+
+// New builds a Cache per opts and registers it in the process-wide
+// invalidation registry under opts.Name.
+func New[K comparable, V any](opts Options[K, V]) (*Cache[K, V], error) {
+	if opts.Name == "" {
+		return nil, fmt.Errorf("cache: Name is required")
+	}
+	maxCost := opts.MaxCost
+	if maxCost <= 0 {
+		maxCost = 1 << 20
+	}
+
+	rc, err := ristretto.NewCache(&ristretto.Config[string, V]{
+		NumCounters: maxCost * 10,
+		MaxCost:     maxCost,
+		BufferItems: 64,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cache: build ristretto cache %q: %w", opts.Name, err)
+	}
+
+	costFunc := opts.CostFunc
+	if costFunc == nil {
+		costFunc = func(V) int64 { return 1 }
+	}
+
+	c := &Cache[K, V]{
+		name:      opts.Name,
+		ttl:       opts.TTL,
+		costFunc:  costFunc,
+		ristretto: rc,
+		metrics:   metricsFor(opts.Name),
+	}
+
+	registerForInvalidation(opts.Name, c)
+	return c, nil
+}
+
+// Get returns the cached value for key, reporting a hit or miss in
+// metrics.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	v, ok := c.ristretto.Get(keyStr(key))
+	if ok {
+		c.metrics.hits.Inc()
+	} else {
+		c.metrics.misses.Inc()
+	}
+	return v, ok
+}
+
+// Set admits value under key with the configured cost function (or
+// the explicit cost if >0), applying the cache's TTL.
+func (c *Cache[K, V]) Set(key K, value V, cost int64) {
+	if cost <= 0 {
+		cost = c.costFunc(value)
+	}
+	c.metrics.costAdded.Add(float64(cost))
+
+	if c.ttl > 0 {
+		c.ristretto.SetWithTTL(keyStr(key), value, cost, c.ttl)
+	} else {
+		c.ristretto.Set(keyStr(key), value, cost)
+	}
+}
+
+// Delete evicts key, if present.
+func (c *Cache[K, V]) Delete(key K) {
+	c.ristretto.Del(keyStr(key))
+	c.metrics.evictions.Inc()
+}
+
+// Clear evicts every entry.
+func (c *Cache[K, V]) Clear() {
+	c.ristretto.Clear()
+}
+
+// Wait blocks until all pending Set calls have been applied.
+// Ristretto admits writes asynchronously, so tests (and anything else
+// that needs a read-your-writes guarantee) should call this after Set.
+func (c *Cache[K, V]) Wait() {
+	c.ristretto.Wait()
+}
+
+// GetOrLoad returns the cached value for key, or calls load on a miss.
+// Concurrent GetOrLoad calls for the same key while a load is in
+// flight share the single in-flight call instead of each hitting the
+// backing store.
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, key K, load Loader[K, V]) (V, error) {
+	if v, ok := c.Get(key); ok {
+		return v, nil
+	}
+
+	v, err, _ := c.group.Do(keyStr(key), func() (interface{}, error) {
+		if v, ok := c.Get(key); ok {
+			return v, nil
+		}
+		value, cost, err := load(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		c.Set(key, value, cost)
+		// Apply the Set before the shared call returns, so the re-check
+		// above sees it for callers that start a new call afterwards.
+		c.Wait()
+		return value, nil
+	})
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	return v.(V), nil
+}
+
+// keyStr renders key as the string ristretto and singleflight actually
+// key on.
+func keyStr[K comparable](key K) string {
+	return fmt.Sprintf("%v", key)
+}
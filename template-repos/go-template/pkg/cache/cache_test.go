@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_GetOrLoad_CoalescesConcurrentMisses(t *testing.T) {
+	c, err := New(Options[string, int]{Name: "test.coalesce", MaxCost: 100})
+	require.NoError(t, err)
+
+	var loads int64
+	load := func(_ context.Context, _ string) (int, int64, error) {
+		atomic.AddInt64(&loads, 1)
+		return 42, 1, nil
+	}
+
+	done := make(chan struct{})
+	for i := 0; i < 10; i++ {
+		go func() {
+			v, err := c.GetOrLoad(context.Background(), "k", load)
+			require.NoError(t, err)
+			require.Equal(t, 42, v)
+			done <- struct{}{}
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		<-done
+	}
+
+	require.LessOrEqual(t, atomic.LoadInt64(&loads), int64(2))
+}
+
+func TestCache_DeleteKey_StringKeyed(t *testing.T) {
+	c, err := New(Options[string, int]{Name: "test.deletekey", MaxCost: 100})
+	require.NoError(t, err)
+
+	c.Set("a", 1, 1)
+	c.Wait()
+	require.True(t, c.DeleteKey("a"))
+	_, ok := c.Get("a")
+	require.False(t, ok)
+}
+
+func TestCache_DeleteKey_NonStringKeyed(t *testing.T) {
+	c, err := New(Options[int, int]{Name: "test.deletekey.int", MaxCost: 100})
+	require.NoError(t, err)
+
+	require.False(t, c.DeleteKey("a"))
+}
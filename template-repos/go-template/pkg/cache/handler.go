@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// AdminHandler returns an http.HandlerFunc for DELETE
+// /internal/cache/{name}, which clears the named cache entirely, or
+// evicts a single entry when called with ?key=.... It's intended to be
+// mounted behind whatever auth the rest of /internal already requires.
+func AdminHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := mux.Vars(r)["name"]
+
+		c, ok := lookupForInvalidation(name)
+		if !ok {
+			http.Error(w, fmt.Sprintf("cache: no cache named %q", name), http.StatusNotFound)
+			return
+		}
+
+		if key := r.URL.Query().Get("key"); key != "" {
+			if !c.DeleteKey(key) {
+				http.Error(w, fmt.Sprintf("cache: %q is not string-keyed, cannot target a single key", name), http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		c.ClearAll()
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
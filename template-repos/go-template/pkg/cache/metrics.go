@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	hitsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_hits_total",
+		Help: "Number of cache lookups that found a value.",
+	}, []string{"cache"})
+
+	missesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_misses_total",
+		Help: "Number of cache lookups that found nothing.",
+	}, []string{"cache"})
+
+	evictionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_evictions_total",
+		Help: "Number of Delete calls, including single-key admin invalidation; Clear and whole-cache invalidation are not counted.",
+	}, []string{"cache"})
+
+	costAddedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_cost_added_total",
+		Help: "Sum of admission cost charged by Set calls.",
+	}, []string{"cache"})
+)
+
+// RegisterMetrics registers the cache_* counters on reg. The caller
+// decides which registry that is: auto-registering on
+// prometheus.DefaultRegisterer here would only be useful if /metrics
+// were always served from it, but the observability package serves the
+// Prometheus fallback from its own private registry, so callers must
+// wire these counters to whichever registry is actually scraped.
+func RegisterMetrics(reg prometheus.Registerer) error {
+	for _, c := range []prometheus.Collector{hitsTotal, missesTotal, evictionsTotal, costAddedTotal} {
+		if err := reg.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// metrics bundles the per-cache-name counter instances so Cache methods
+// don't re-resolve labels on every call.
+type metrics struct {
+	hits      prometheus.Counter
+	misses    prometheus.Counter
+	evictions prometheus.Counter
+	costAdded prometheus.Counter
+}
+
+var (
+	metricsMu    sync.Mutex
+	metricsCache = map[string]*metrics{}
+)
+
+func metricsFor(name string) *metrics {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+
+	if m, ok := metricsCache[name]; ok {
+		return m
+	}
+
+	m := &metrics{
+		hits:      hitsTotal.WithLabelValues(name),
+		misses:    missesTotal.WithLabelValues(name),
+		evictions: evictionsTotal.WithLabelValues(name),
+		costAdded: costAddedTotal.WithLabelValues(name),
+	}
+	metricsCache[name] = m
+	return m
+}
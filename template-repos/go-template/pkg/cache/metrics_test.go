@@ -0,0 +1,25 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterMetrics_ExposesCacheCounters(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	require.NoError(t, RegisterMetrics(reg))
+
+	c, err := New(Options[string, int]{Name: "test.metrics", MaxCost: 100})
+	require.NoError(t, err)
+
+	c.Set("a", 1, 1)
+	c.Wait()
+	_, _ = c.Get("a")
+
+	count, err := testutil.GatherAndCount(reg, "cache_hits_total", "cache_misses_total")
+	require.NoError(t, err)
+	require.Greater(t, count, 0)
+}
@@ -0,0 +1,48 @@
+package cache
+
+import "sync"
+
+// invalidator is the narrow interface the admin HTTP handler needs,
+// independent of a cache's key/value type parameters.
+type invalidator interface {
+	ClearAll()
+	// DeleteKey evicts the entry for key, if this cache is keyed by
+	// string. It returns false (doing nothing) for caches keyed by any
+	// other type, since there's no general way to parse an HTTP query
+	// param into an arbitrary comparable key type.
+	DeleteKey(key string) bool
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]invalidator{}
+)
+
+func registerForInvalidation(name string, c invalidator) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = c
+}
+
+func lookupForInvalidation(name string) (invalidator, bool) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	c, ok := registry[name]
+	return c, ok
+}
+
+// ClearAll evicts every entry in this cache.
+func (c *Cache[K, V]) ClearAll() {
+	c.Clear()
+}
+
+// DeleteKey implements invalidator for string-keyed caches; see the
+// interface doc for why other key types are a no-op.
+func (c *Cache[K, V]) DeleteKey(key string) bool {
+	typedKey, ok := any(key).(K)
+	if !ok {
+		return false
+	}
+	c.Delete(typedKey)
+	return true
+}
@@ -0,0 +1,251 @@
+package eventstore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// ClickHouseConfig controls connection and batching behavior for the
+// ClickHouse sink.
+type ClickHouseConfig struct {
+	Addr     string
+	Database string
+	Table    string // defaults to "automation_events"
+
+	// BatchSize flushes once this many events are buffered.
+	BatchSize int
+	// FlushInterval flushes whatever is buffered, even if BatchSize
+	// hasn't been reached.
+	FlushInterval time.Duration
+
+	// AsyncInsert enables ClickHouse's async_insert setting so the
+	// client doesn't wait for the part to be written to disk.
+	AsyncInsert bool
+}
+
+func (c ClickHouseConfig) withDefaults() ClickHouseConfig {
+	if c.Table == "" {
+		c.Table = "automation_events"
+	}
+	if c.BatchSize == 0 {
+		c.BatchSize = 1000
+	}
+	if c.FlushInterval == 0 {
+		c.FlushInterval = time.Second
+	}
+	return c
+}
+
+// ClickHouseSink is a Sink that batches rows in memory and flushes them
+// as a single columnar insert over the ch-go native protocol, rather
+// than row-by-row.
+type ClickHouseSink struct {
+	cfg    ClickHouseConfig
+	client *ch.Client
+
+	mu      sync.Mutex
+	batch   []Event
+	flushCh chan struct{}
+	closed  bool
+
+	wg sync.WaitGroup
+}
+
+// NewClickHouseSink dials ClickHouse at cfg.Addr and starts the
+// background flush loop.
+func NewClickHouseSink(ctx context.Context, cfg ClickHouseConfig) (*ClickHouseSink, error) {
+	cfg = cfg.withDefaults()
+
+	client, err := ch.Dial(ctx, ch.Options{
+		Address:  cfg.Addr,
+		Database: cfg.Database,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("eventstore: dial clickhouse: %w", err)
+	}
+
+	s := &ClickHouseSink{
+		cfg:     cfg,
+		client:  client,
+		flushCh: make(chan struct{}, 1),
+	}
+
+	s.wg.Add(1)
+	go s.flushLoop()
+
+	return s, nil
+}
+
+func (s *ClickHouseSink) Write(ctx context.Context, ev Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return fmt.Errorf("eventstore: sink is closed")
+	}
+
+	s.batch = append(s.batch, ev)
+	if len(s.batch) >= s.cfg.BatchSize {
+		select {
+		case s.flushCh <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+func (s *ClickHouseSink) flushLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.flushWithBackoff(context.Background())
+		case <-s.flushCh:
+			s.flushWithBackoff(context.Background())
+		}
+
+		s.mu.Lock()
+		done := s.closed && len(s.batch) == 0
+		s.mu.Unlock()
+		if done {
+			return
+		}
+	}
+}
+
+// flushWithBackoff retries transient connection errors with exponential
+// backoff instead of dropping the batch.
+func (s *ClickHouseSink) flushWithBackoff(ctx context.Context) {
+	backoff := 100 * time.Millisecond
+	const maxBackoff = 10 * time.Second
+
+	for attempt := 0; ; attempt++ {
+		err := s.Flush(ctx)
+		if err == nil {
+			return
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+		if attempt >= 5 {
+			log.Printf("eventstore: flush failed after %d attempts, batch kept for the next flush: %v", attempt+1, err)
+			return
+		}
+	}
+}
+
+func (s *ClickHouseSink) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	batch := s.batch
+	s.batch = nil
+	s.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	block := eventsToBlock(batch)
+
+	err := s.client.Do(ctx, ch.Query{
+		Body:  fmt.Sprintf("INSERT INTO %s VALUES", s.cfg.Table),
+		Input: block.cols,
+		Settings: []ch.Setting{
+			{Key: "async_insert", Value: boolSetting(s.cfg.AsyncInsert), Important: true},
+		},
+	})
+	if err != nil {
+		// Put the batch back so the next attempt isn't empty-handed.
+		s.mu.Lock()
+		s.batch = append(batch, s.batch...)
+		s.mu.Unlock()
+		return fmt.Errorf("eventstore: insert batch: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ClickHouseSink) Close(ctx context.Context) error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	select {
+	case s.flushCh <- struct{}{}:
+	default:
+	}
+
+	s.wg.Wait()
+	err := s.Flush(ctx)
+	if cerr := s.client.Close(); err == nil && cerr != nil {
+		err = fmt.Errorf("eventstore: close clickhouse client: %w", cerr)
+	}
+	return err
+}
+
+func boolSetting(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+// eventColumns holds one column per Event field, built fresh for each
+// batch so ch-go can serialize them as a single column-block insert.
+type eventColumns struct {
+	runID      *proto.ColStr
+	workflowID *proto.ColStr
+	eventType  *proto.ColStr
+	step       *proto.ColStr
+	output     *proto.ColStr
+	errCol     *proto.ColStr
+	ts         *proto.ColDateTime64
+
+	cols proto.Input
+}
+
+func eventsToBlock(events []Event) eventColumns {
+	c := eventColumns{
+		runID:      new(proto.ColStr),
+		workflowID: new(proto.ColStr),
+		eventType:  new(proto.ColStr),
+		step:       new(proto.ColStr),
+		output:     new(proto.ColStr),
+		errCol:     new(proto.ColStr),
+		ts:         new(proto.ColDateTime64).WithPrecision(proto.PrecisionNano),
+	}
+
+	for _, ev := range events {
+		c.runID.Append(ev.RunID)
+		c.workflowID.Append(ev.WorkflowID)
+		c.eventType.Append(string(ev.Type))
+		c.step.Append(ev.Step)
+		c.output.Append(ev.Output)
+		c.errCol.Append(ev.Err)
+		c.ts.Append(time.Unix(0, ev.Timestamp))
+	}
+
+	c.cols = proto.Input{
+		{Name: "run_id", Data: c.runID},
+		{Name: "workflow_id", Data: c.workflowID},
+		{Name: "event_type", Data: c.eventType},
+		{Name: "step", Data: c.step},
+		{Name: "output", Data: c.output},
+		{Name: "error", Data: c.errCol},
+		{Name: "event_ts", Data: c.ts},
+	}
+
+	return c
+}
@@ -0,0 +1,46 @@
+// Package eventstore records automation run events (start/finish/step/
+// output) into a pluggable sink for history and analytics. The
+// ClickHouse implementation batches columnar inserts over the native
+// protocol for high-throughput ingestion.
+package eventstore
+
+import "context"
+
+// EventType enumerates the kinds of events emitted during a run.
+type EventType string
+
+const (
+	EventStart  EventType = "start"
+	EventFinish EventType = "finish"
+	EventStep   EventType = "step"
+	EventOutput EventType = "output"
+)
+
+// Event is a single row of automation run history.
+type Event struct {
+	RunID      string
+	WorkflowID string
+	Type       EventType
+	Step       string
+	Output     string
+	Err        string
+	Timestamp  int64 // unix nanos
+}
+
+// Sink accepts automation run events for durable storage. Implementations
+// are expected to buffer internally; Write should not block on a
+// network round trip per call.
+type Sink interface {
+	// Write enqueues ev for persistence. It returns an error only if the
+	// event was rejected outright (e.g. the sink is closed); buffering
+	// and flush failures are retried internally and logged, not
+	// surfaced here.
+	Write(ctx context.Context, ev Event) error
+
+	// Flush blocks until all buffered events have been durably written
+	// or ctx is done.
+	Flush(ctx context.Context) error
+
+	// Close flushes and releases the sink's resources.
+	Close(ctx context.Context) error
+}
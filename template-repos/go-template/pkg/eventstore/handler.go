@@ -0,0 +1,150 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// Querier is implemented by ClickHouseSink and lets the HTTP handler
+// read events without depending on the sink's write-path internals.
+type Querier interface {
+	QueryEvents(ctx context.Context, f EventFilter) ([]Event, error)
+}
+
+// EventFilter narrows a /events query by time range and workflow.
+type EventFilter struct {
+	WorkflowID string
+	Since      time.Time
+	Until      time.Time
+	Limit      int
+}
+
+// QueryEvents implements Querier for ClickHouseSink.
+func (s *ClickHouseSink) QueryEvents(ctx context.Context, f EventFilter) ([]Event, error) {
+	if f.Limit <= 0 || f.Limit > 10000 {
+		f.Limit = 1000
+	}
+
+	var (
+		runID, workflowID, eventType, step, output, errCol proto.ColStr
+		ts                                                 proto.ColDateTime64
+	)
+
+	// workflow_id is optional (filterFromQuery allows an empty value for
+	// "all workflows"), so the predicate must not filter rows out when
+	// it's unset. Query parameters are server-side bindings referenced
+	// as {name:Type} in the query text, not the "@name" placeholders
+	// ch-go's HTTP interface uses elsewhere.
+	query := fmt.Sprintf(
+		`SELECT run_id, workflow_id, event_type, step, output, error, event_ts
+		 FROM %s
+		 WHERE ({workflow_id:String} = '' OR workflow_id = {workflow_id:String})
+		   AND toUnixTimestamp64Nano(event_ts) >= {since:Int64}
+		   AND toUnixTimestamp64Nano(event_ts) <= {until:Int64}
+		 ORDER BY event_ts DESC
+		 LIMIT {limit:UInt64}`, s.cfg.Table)
+
+	var events []Event
+	err := s.client.Do(ctx, ch.Query{
+		Body: query,
+		Parameters: ch.Parameters(map[string]any{
+			"workflow_id": f.WorkflowID,
+			"since":       f.Since.UnixNano(),
+			"until":       f.Until.UnixNano(),
+			"limit":       f.Limit,
+		}),
+		Result: proto.Results{
+			{Name: "run_id", Data: &runID},
+			{Name: "workflow_id", Data: &workflowID},
+			{Name: "event_type", Data: &eventType},
+			{Name: "step", Data: &step},
+			{Name: "output", Data: &output},
+			{Name: "error", Data: &errCol},
+			{Name: "event_ts", Data: &ts},
+		},
+		OnResult: func(ctx context.Context, block proto.Block) error {
+			for i := 0; i < block.Rows; i++ {
+				events = append(events, Event{
+					RunID:      runID.Row(i),
+					WorkflowID: workflowID.Row(i),
+					Type:       EventType(eventType.Row(i)),
+					Step:       step.Row(i),
+					Output:     output.Row(i),
+					Err:        errCol.Row(i),
+					Timestamp:  ts.Row(i).UnixNano(),
+				})
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("eventstore: query events: %w", err)
+	}
+
+	return events, nil
+}
+
+// Handler returns an http.HandlerFunc for GET /events that reads recent
+// automation events, filtered by workflow_id/since/until query params.
+func Handler(q Querier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f, err := filterFromQuery(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		events, err := q.QueryEvents(r.Context(), f)
+		if err != nil {
+			http.Error(w, "eventstore: query failed", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(events)
+	}
+}
+
+func filterFromQuery(r *http.Request) (EventFilter, error) {
+	q := r.URL.Query()
+
+	f := EventFilter{
+		WorkflowID: q.Get("workflow_id"),
+		Until:      time.Now(),
+	}
+
+	if v := q.Get("since"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return EventFilter{}, fmt.Errorf("eventstore: invalid since: %w", err)
+		}
+		f.Since = t
+	} else {
+		f.Since = f.Until.Add(-time.Hour)
+	}
+
+	if v := q.Get("until"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return EventFilter{}, fmt.Errorf("eventstore: invalid until: %w", err)
+		}
+		f.Until = t
+	}
+
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return EventFilter{}, fmt.Errorf("eventstore: invalid limit: %w", err)
+		}
+		f.Limit = n
+	}
+
+	return f, nil
+}
@@ -0,0 +1,50 @@
+package eventstore
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQuerier lets Handler's wiring (including that an empty
+// WorkflowID reaches QueryEvents unfiltered) be exercised without a
+// real ClickHouse connection.
+type fakeQuerier struct {
+	gotFilter EventFilter
+	events    []Event
+}
+
+func (f *fakeQuerier) QueryEvents(_ context.Context, filter EventFilter) ([]Event, error) {
+	f.gotFilter = filter
+	return f.events, nil
+}
+
+func TestHandler_OmitsWorkflowIDWhenNotRequested(t *testing.T) {
+	q := &fakeQuerier{events: []Event{{RunID: "run-1", WorkflowID: "wf-1"}}}
+
+	r := httptest.NewRequest(http.MethodGet, "/events", nil)
+	w := httptest.NewRecorder()
+	Handler(q)(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, q.gotFilter.WorkflowID)
+}
+
+func TestFilterFromQuery_Defaults(t *testing.T) {
+	r := httptest.NewRequest("GET", "/events?workflow_id=wf-1", nil)
+
+	f, err := filterFromQuery(r)
+	require.NoError(t, err)
+	require.Equal(t, "wf-1", f.WorkflowID)
+	require.True(t, f.Since.Before(f.Until))
+}
+
+func TestFilterFromQuery_InvalidSince(t *testing.T) {
+	r := httptest.NewRequest("GET", "/events?since=not-a-time", nil)
+
+	_, err := filterFromQuery(r)
+	require.Error(t, err)
+}
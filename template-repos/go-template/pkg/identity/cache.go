@@ -0,0 +1,46 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+
+	"github.com/your-org/project-name/pkg/cache"
+)
+
+// decision is what gets cached for a SPIFFE ID: whether it's allowed,
+// and if not, why (for the 403 body).
+type decision struct {
+	allowed bool
+	reason  string
+}
+
+// NewAuthzCache builds the cache backing CachedMatcher, keyed by
+// SPIFFE ID string.
+func NewAuthzCache() (*cache.Cache[string, decision], error) {
+	return cache.New(cache.Options[string, decision]{
+		Name:    "identity.authz",
+		MaxCost: 10000,
+	})
+}
+
+// CachedMatcher wraps matcher with c so repeated calls from the same
+// caller don't re-walk the allowlist on every request.
+func CachedMatcher(matcher func(spiffeid.ID) error, c *cache.Cache[string, decision]) func(spiffeid.ID) error {
+	return func(id spiffeid.ID) error {
+		d, err := c.GetOrLoad(context.Background(), id.String(), func(_ context.Context, _ string) (decision, int64, error) {
+			if err := matcher(id); err != nil {
+				return decision{allowed: false, reason: err.Error()}, 1, nil
+			}
+			return decision{allowed: true}, 1, nil
+		})
+		if err != nil {
+			return err
+		}
+		if !d.allowed {
+			return fmt.Errorf("%s", d.reason)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,110 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+)
+
+// NewServer returns an *http.Server configured for mTLS: the server
+// presents its own X.509-SVID and accepts client connections whose
+// SVID is authorized by authorizer. handler is wrapped with
+// PeerMiddleware so downstream handlers can read the caller's SPIFFE
+// ID from the request context.
+func NewServer(ctx context.Context, addr string, src *Source, authorizer tlsconfig.Authorizer, handler http.Handler) (*http.Server, error) {
+	x509src, err := src.X509(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("identity: build server TLS config: %w", err)
+	}
+
+	tlsCfg := tlsconfig.MTLSServerConfig(x509src, x509src, authorizer)
+
+	return &http.Server{
+		Addr:              addr,
+		Handler:           PeerMiddleware(handler),
+		TLSConfig:         tlsCfg,
+		ReadHeaderTimeout: 10 * time.Second,
+	}, nil
+}
+
+// Dialer returns an http.Client that presents this workload's
+// X.509-SVID and only trusts servers whose SVID is authorized by
+// authorizer, for calls between automation workers.
+func Dialer(ctx context.Context, src *Source, authorizer tlsconfig.Authorizer) (*http.Client, error) {
+	x509src, err := src.X509(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("identity: build client TLS config: %w", err)
+	}
+
+	tlsCfg := tlsconfig.MTLSClientConfig(x509src, x509src, authorizer)
+
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: tlsCfg,
+		},
+	}, nil
+}
+
+// AllowedMatcher builds a spiffeid.Matcher from a set of SPIFFE ID
+// strings and trust domains loaded from config. Entries that parse as
+// a full SPIFFE ID are matched exactly; entries that parse as a bare
+// trust domain allow any ID within it; entries of the form
+// "spiffe://domain/path/*" allow any ID whose path has that prefix.
+// If allowed is empty entirely, every ID in trustDomain is allowed —
+// but as soon as any entry is given, only those entries (plus any
+// explicit trust-domain entries among them) are authorized, so a
+// caller that lists specific IDs can't be silently widened back out to
+// the whole trust domain.
+func AllowedMatcher(trustDomain string, allowed []string) (spiffeid.Matcher, error) {
+	td, err := spiffeid.TrustDomainFromString(trustDomain)
+	if err != nil {
+		return nil, fmt.Errorf("identity: parse trust domain %q: %w", trustDomain, err)
+	}
+
+	var ids []spiffeid.ID
+	var domains []spiffeid.TrustDomain
+	var prefixes []string
+	for _, entry := range allowed {
+		if strings.HasSuffix(entry, "/*") {
+			prefixes = append(prefixes, strings.TrimSuffix(entry, "*"))
+			continue
+		}
+		if id, err := spiffeid.FromString(entry); err == nil {
+			ids = append(ids, id)
+			continue
+		}
+		if d, err := spiffeid.TrustDomainFromString(entry); err == nil {
+			domains = append(domains, d)
+			continue
+		}
+		return nil, fmt.Errorf("identity: %q is neither a SPIFFE ID, a trust domain, nor a \"spiffe://domain/path/*\" prefix", entry)
+	}
+
+	if len(allowed) == 0 {
+		domains = []spiffeid.TrustDomain{td}
+	}
+
+	return func(actual spiffeid.ID) error {
+		for _, id := range ids {
+			if actual == id {
+				return nil
+			}
+		}
+		for _, d := range domains {
+			if actual.MemberOf(d) {
+				return nil
+			}
+		}
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(actual.String(), prefix) {
+				return nil
+			}
+		}
+		return fmt.Errorf("identity: %s is not in the allowlist", actual)
+	}, nil
+}
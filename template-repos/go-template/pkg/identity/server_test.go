@@ -0,0 +1,56 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/require"
+)
+
+func mustID(t *testing.T, s string) spiffeid.ID {
+	t.Helper()
+	id, err := spiffeid.FromString(s)
+	require.NoError(t, err)
+	return id
+}
+
+func TestAllowedMatcher(t *testing.T) {
+	matcher, err := AllowedMatcher("automation.example.com", []string{
+		"spiffe://automation.example.com/worker/batch",
+	})
+	require.NoError(t, err)
+
+	allowed := mustID(t, "spiffe://automation.example.com/worker/batch")
+	require.NoError(t, matcher(allowed))
+
+	denied := mustID(t, "spiffe://other.example.com/worker/batch")
+	require.Error(t, matcher(denied))
+}
+
+func TestAllowedMatcher_DeniesUnlistedIDInSameTrustDomain(t *testing.T) {
+	matcher, err := AllowedMatcher("automation.example.com", []string{
+		"spiffe://automation.example.com/worker/batch",
+	})
+	require.NoError(t, err)
+
+	denied := mustID(t, "spiffe://automation.example.com/attacker")
+	require.Error(t, matcher(denied))
+}
+
+func TestAllowedMatcher_FallsBackToTrustDomain(t *testing.T) {
+	matcher, err := AllowedMatcher("automation.example.com", nil)
+	require.NoError(t, err)
+
+	id := mustID(t, "spiffe://automation.example.com/anything")
+	require.NoError(t, matcher(id))
+}
+
+func TestAllowedMatcher_PathPrefix(t *testing.T) {
+	matcher, err := AllowedMatcher("automation.example.com", []string{
+		"spiffe://automation.example.com/worker/*",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, matcher(mustID(t, "spiffe://automation.example.com/worker/batch")))
+	require.Error(t, matcher(mustID(t, "spiffe://automation.example.com/controller")))
+}
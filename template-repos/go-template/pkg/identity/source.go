@@ -0,0 +1,111 @@
+// Package identity provides SPIFFE/SPIRE-backed workload identity for
+// mutual TLS between automation components, replacing ad-hoc static
+// certs and bearer tokens with rotating X.509-SVIDs.
+package identity
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/workloadapi"
+)
+
+// Source wraps the SPIRE Workload API client, lazily dialing it on
+// first use and handing back the same underlying X509Source/JWTSource
+// for the lifetime of the process so SVID rotation is transparent to
+// callers.
+type Source struct {
+	agentSocket string
+
+	mu      sync.Mutex
+	x509src *workloadapi.X509Source
+	jwtsrc  *workloadapi.JWTSource
+}
+
+// NewSource returns a Source that will dial the Workload API at
+// agentSocket the first time an X.509 or JWT source is requested.
+func NewSource(agentSocket string) *Source {
+	return &Source{agentSocket: agentSocket}
+}
+
+// X509 returns the lazily-initialized X509Source, dialing the Workload
+// API if this is the first call.
+func (s *Source) X509(ctx context.Context) (*workloadapi.X509Source, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.x509src != nil {
+		return s.x509src, nil
+	}
+
+	src, err := workloadapi.NewX509Source(ctx, workloadapi.WithClientOptions(
+		workloadapi.WithAddr(s.agentSocket),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("identity: dial workload API for X509Source: %w", err)
+	}
+
+	s.x509src = src
+	return s.x509src, nil
+}
+
+// JWT returns the lazily-initialized JWTSource, dialing the Workload
+// API if this is the first call.
+func (s *Source) JWT(ctx context.Context) (*workloadapi.JWTSource, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.jwtsrc != nil {
+		return s.jwtsrc, nil
+	}
+
+	src, err := workloadapi.NewJWTSource(ctx, workloadapi.WithClientOptions(
+		workloadapi.WithAddr(s.agentSocket),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("identity: dial workload API for JWTSource: %w", err)
+	}
+
+	s.jwtsrc = src
+	return s.jwtsrc, nil
+}
+
+// SVID returns the SPIFFE ID of the current X.509-SVID, forcing lazy
+// init if needed.
+func (s *Source) SVID(ctx context.Context) (spiffeid.ID, error) {
+	src, err := s.X509(ctx)
+	if err != nil {
+		return spiffeid.ID{}, err
+	}
+
+	svid, err := src.GetX509SVID()
+	if err != nil {
+		return spiffeid.ID{}, fmt.Errorf("identity: fetch current SVID: %w", err)
+	}
+
+	return svid.ID, nil
+}
+
+// Close releases any Workload API connections that have been opened.
+func (s *Source) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var errs []error
+	if s.x509src != nil {
+		if err := s.x509src.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if s.jwtsrc != nil {
+		if err := s.jwtsrc.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("identity: close source(s): %v", errs)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package observability
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// tracingTransport injects the current span's W3C traceparent into
+// outbound requests made by automation tasks.
+type tracingTransport struct {
+	base http.RoundTripper
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	otel.GetTextMapPropagator().Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+	return t.base.RoundTrip(req)
+}
+
+// InstrumentedClient wraps base (or http.DefaultTransport if base is
+// nil) so every outbound call from an automation task propagates the
+// caller's trace context.
+func InstrumentedClient(base http.RoundTripper) *http.Client {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &http.Client{Transport: &tracingTransport{base: base}}
+}
@@ -0,0 +1,21 @@
+package observability
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// StartJobSpan starts a child span for a background job handler,
+// tagged with the job's id and type so traces can be filtered per
+// workflow in the backend.
+func StartJobSpan(ctx context.Context, jobType, jobID string) (context.Context, trace.Span) {
+	tracer := otel.Tracer(instrumentationName)
+	return tracer.Start(ctx, "job."+jobType,
+		trace.WithAttributes(
+			attrString("job.id", jobID),
+			attrString("job.type", jobType),
+		),
+	)
+}
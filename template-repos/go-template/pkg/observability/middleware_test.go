@@ -0,0 +1,28 @@
+package observability
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteTemplate_UsesMuxTemplate(t *testing.T) {
+	r := mux.NewRouter()
+	var got string
+	r.HandleFunc("/workflows/{id}", func(w http.ResponseWriter, req *http.Request) {
+		got = routeTemplate(req)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/workflows/42", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.Equal(t, "GET /workflows/{id}", got)
+}
+
+func TestRouteTemplate_FallsBackToRawPath(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/unmatched", nil)
+	require.Equal(t, "GET /unmatched", routeTemplate(req))
+}
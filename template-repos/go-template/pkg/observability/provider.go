@@ -0,0 +1,182 @@
+// Package observability wires OpenTelemetry traces and metrics through
+// the HTTP router and background job runners, with an OTLP exporter by
+// default and a Prometheus fallback for users without a collector.
+package observability
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// Config controls how the TracerProvider and MeterProvider are built.
+type Config struct {
+	// ServiceName and ServiceVersion populate the resource attributes
+	// attached to every span and metric.
+	ServiceName    string
+	ServiceVersion string
+
+	// OTLPEndpoint is the collector address, e.g. "otel-collector:4317".
+	// If empty, metrics fall back to an in-process Prometheus exporter
+	// and tracing is disabled.
+	OTLPEndpoint string
+
+	// SamplerRatio is the fraction of traces sampled when OTLPEndpoint
+	// is set, in [0, 1].
+	SamplerRatio float64
+}
+
+// ConfigFromEnv reads OTEL_EXPORTER_OTLP_ENDPOINT, OTEL_TRACES_SAMPLER_RATIO,
+// OTEL_SERVICE_NAME, and OTEL_SERVICE_VERSION.
+func ConfigFromEnv() Config {
+	ratio := 1.0
+	if v := os.Getenv("OTEL_TRACES_SAMPLER_RATIO"); v != "" {
+		if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+			ratio = parsed
+		}
+	}
+
+	name := os.Getenv("OTEL_SERVICE_NAME")
+	if name == "" {
+		name = "automation-controller"
+	}
+
+	return Config{
+		ServiceName:    name,
+		ServiceVersion: os.Getenv("OTEL_SERVICE_VERSION"),
+		OTLPEndpoint:   os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"),
+		SamplerRatio:   ratio,
+	}
+}
+
+// Providers bundles the SDK providers along with their shutdown hook.
+type Providers struct {
+	TracerProvider *sdktrace.TracerProvider
+	MeterProvider  *metric.MeterProvider
+
+	// Registry is non-nil only when NewProviders fell back to the
+	// in-process Prometheus exporter (no OTLPEndpoint configured). The
+	// caller mounts it at /metrics; otherwise the fallback metrics are
+	// collected but never scraped by anything.
+	Registry *prometheus.Registry
+
+	shutdown func(context.Context) error
+}
+
+// Shutdown flushes and closes the providers, and must be called before
+// process exit.
+func (p *Providers) Shutdown(ctx context.Context) error {
+	return p.shutdown(ctx)
+}
+
+// NewProviders builds the TracerProvider and MeterProvider described by
+// cfg, registers them as the global providers, and sets up W3C
+// traceparent propagation.
+func NewProviders(ctx context.Context, cfg Config) (*Providers, error) {
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceName(cfg.ServiceName),
+			semconv.ServiceVersion(cfg.ServiceVersion),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("observability: build resource: %w", err)
+	}
+
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if cfg.OTLPEndpoint == "" {
+		return newPrometheusProviders(res)
+	}
+
+	return newOTLPProviders(ctx, cfg, res)
+}
+
+func newOTLPProviders(ctx context.Context, cfg Config, res *resource.Resource) (*Providers, error) {
+	traceExp, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint), otlptracegrpc.WithInsecure())
+	if err != nil {
+		return nil, fmt.Errorf("observability: build OTLP trace exporter: %w", err)
+	}
+
+	metricExp, err := otlpmetricgrpc.New(ctx, otlpmetricgrpc.WithEndpoint(cfg.OTLPEndpoint), otlpmetricgrpc.WithInsecure())
+	if err != nil {
+		return nil, fmt.Errorf("observability: build OTLP metric exporter: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(res),
+		sdktrace.WithBatcher(traceExp),
+		sdktrace.WithSampler(sdktrace.TraceIDRatioBased(cfg.SamplerRatio)),
+	)
+
+	mp := metric.NewMeterProvider(
+		metric.WithResource(res),
+		metric.WithReader(metric.NewPeriodicReader(metricExp)),
+	)
+
+	otel.SetTracerProvider(tp)
+	otel.SetMeterProvider(mp)
+
+	return &Providers{
+		TracerProvider: tp,
+		MeterProvider:  mp,
+		shutdown: func(ctx context.Context) error {
+			if err := tp.Shutdown(ctx); err != nil {
+				return err
+			}
+			return mp.Shutdown(ctx)
+		},
+	}, nil
+}
+
+func newPrometheusProviders(res *resource.Resource) (*Providers, error) {
+	// A dedicated registry (rather than prometheus.DefaultRegisterer) so
+	// the caller can hand promhttp exactly this registry's collectors,
+	// and with EnableOpenMetrics so traced metric points carry the
+	// exemplars the OTLP path gets for free.
+	reg := prometheus.NewRegistry()
+	exp, err := otelprometheus.New(otelprometheus.WithRegisterer(reg))
+	if err != nil {
+		return nil, fmt.Errorf("observability: build Prometheus exporter: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.NeverSample()),
+	)
+	mp := metric.NewMeterProvider(
+		metric.WithResource(res),
+		metric.WithReader(exp),
+	)
+
+	otel.SetTracerProvider(tp)
+	otel.SetMeterProvider(mp)
+
+	return &Providers{
+		TracerProvider: tp,
+		MeterProvider:  mp,
+		Registry:       reg,
+		shutdown: func(ctx context.Context) error {
+			if err := tp.Shutdown(ctx); err != nil {
+				return err
+			}
+			return mp.Shutdown(ctx)
+		},
+	}, nil
+}
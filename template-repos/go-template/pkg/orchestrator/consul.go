@@ -0,0 +1,128 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	consulapi "github.com/hashicorp/consul/api"
+)
+
+// RegisterService registers the automation controller itself as a
+// Consul service, with a health check tied to its own /healthz route
+// so Consul only routes traffic to it once the HTTP server is actually
+// answering.
+func RegisterService(client *consulapi.Client, serviceID, serviceName, addr string, port int) error {
+	reg := &consulapi.AgentServiceRegistration{
+		ID:      serviceID,
+		Name:    serviceName,
+		Address: addr,
+		Port:    port,
+		Check: &consulapi.AgentServiceCheck{
+			HTTP:                           fmt.Sprintf("http://%s:%d/healthz", addr, port),
+			Interval:                       "10s",
+			Timeout:                        "2s",
+			DeregisterCriticalServiceAfter: "1m",
+		},
+	}
+	return client.Agent().ServiceRegister(reg)
+}
+
+// DeregisterService removes the controller's own service registration,
+// e.g. on graceful shutdown.
+func DeregisterService(client *consulapi.Client, serviceID string) error {
+	return client.Agent().ServiceDeregister(serviceID)
+}
+
+// KVWatcher polls a Consul KV prefix for changes using blocking
+// queries, invoking onChange with the full set of key/value pairs
+// whenever the index advances. It's used for dynamic config and
+// feature flags that operators want to change without a restart.
+type KVWatcher struct {
+	client *consulapi.Client
+	prefix string
+}
+
+// NewKVWatcher watches prefix on the given Consul client.
+func NewKVWatcher(client *consulapi.Client, prefix string) *KVWatcher {
+	return &KVWatcher{client: client, prefix: prefix}
+}
+
+// Watch blocks until ctx is done, calling onChange each time the
+// watched prefix's KV data changes.
+func (w *KVWatcher) Watch(ctx context.Context, onChange func(map[string]string)) error {
+	var waitIndex uint64
+	backoff := kvWatchMinBackoff
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		pairs, meta, err := w.client.KV().List(w.prefix, (&consulapi.QueryOptions{
+			WaitIndex: waitIndex,
+		}).WithContext(ctx))
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// A down/unreachable Consul agent would otherwise spin
+			// this loop as fast as the client can fail, so back off
+			// between retries instead of hammering it.
+			if !sleepOrDone(ctx, backoff) {
+				return ctx.Err()
+			}
+			backoff = nextBackoff(backoff)
+			continue
+		}
+		backoff = kvWatchMinBackoff
+
+		if meta.LastIndex == waitIndex {
+			continue
+		}
+		waitIndex = meta.LastIndex
+
+		values := make(map[string]string, len(pairs))
+		for _, pair := range pairs {
+			values[pair.Key] = string(pair.Value)
+		}
+		onChange(values)
+	}
+}
+
+const (
+	kvWatchMinBackoff = 500 * time.Millisecond
+	kvWatchMaxBackoff = 30 * time.Second
+)
+
+func nextBackoff(cur time.Duration) time.Duration {
+	next := cur * 2
+	if next > kvWatchMaxBackoff {
+		return kvWatchMaxBackoff
+	}
+	return next
+}
+
+// sleepOrDone waits for d, returning false early if ctx is done first.
+func sleepOrDone(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// HealthCheckHandler answers every request with 200 "ok", for mounting
+// at /healthz (the path Consul's service check above polls). The
+// command's router serves /healthz with its own handler of the same
+// behaviour, not with this one.
+func HealthCheckHandler(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
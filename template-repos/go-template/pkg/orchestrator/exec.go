@@ -0,0 +1,135 @@
+package orchestrator
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync"
+)
+
+// ExecBackend runs tasks as local subprocesses. It's the default
+// backend and exists so existing single-node deployments keep working
+// unchanged when the Nomad/Consul backend isn't configured.
+type ExecBackend struct {
+	mu    sync.Mutex
+	tasks map[string]*execTask
+}
+
+type execTask struct {
+	cmd    *exec.Cmd
+	status TaskStatus
+	logs   *logBuffer
+}
+
+// NewExecBackend returns a Backend that runs tasks as local
+// subprocesses.
+func NewExecBackend() *ExecBackend {
+	return &ExecBackend{tasks: make(map[string]*execTask)}
+}
+
+func (b *ExecBackend) Submit(ctx context.Context, def TaskDefinition) error {
+	cmd := exec.CommandContext(ctx, def.Command, def.Args...)
+	for k, v := range def.Env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("orchestrator: attach stdout for %s: %w", def.ID, err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("orchestrator: attach stderr for %s: %w", def.ID, err)
+	}
+
+	task := &execTask{
+		cmd:    cmd,
+		status: TaskStatus{ID: def.ID, State: TaskPending},
+		logs:   newLogBuffer(),
+	}
+
+	b.mu.Lock()
+	b.tasks[def.ID] = task
+	b.mu.Unlock()
+
+	if err := cmd.Start(); err != nil {
+		task.status = TaskStatus{ID: def.ID, State: TaskFailed, Err: err.Error()}
+		return fmt.Errorf("orchestrator: start %s: %w", def.ID, err)
+	}
+	task.status.State = TaskRunning
+
+	// cmd.Wait must not run until both pipes have been fully read (its
+	// own doc comment says so), so wait for both streamLines calls to
+	// hit EOF before calling it. logBuffer.push never blocks, so
+	// neither goroutine can stall waiting for a consumer that may
+	// never show up.
+	var streams sync.WaitGroup
+	streams.Add(2)
+	go func() {
+		defer streams.Done()
+		streamLines(def.ID, "stdout", stdout, task.logs)
+	}()
+	go func() {
+		defer streams.Done()
+		streamLines(def.ID, "stderr", stderr, task.logs)
+	}()
+
+	go func() {
+		streams.Wait()
+		err := cmd.Wait()
+		task.logs.closeBuffer()
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if err != nil {
+			task.status = TaskStatus{ID: def.ID, State: TaskFailed, Err: err.Error()}
+			return
+		}
+		task.status = TaskStatus{ID: def.ID, State: TaskComplete}
+	}()
+
+	return nil
+}
+
+func streamLines(taskID, stream string, r io.Reader, logs *logBuffer) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		logs.push(LogLine{TaskID: taskID, Stream: stream, Line: scanner.Text()})
+	}
+}
+
+func (b *ExecBackend) Status(ctx context.Context, taskID string) (TaskStatus, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	task, ok := b.tasks[taskID]
+	if !ok {
+		return TaskStatus{}, fmt.Errorf("orchestrator: unknown task %q", taskID)
+	}
+	return task.status, nil
+}
+
+func (b *ExecBackend) Logs(ctx context.Context, taskID string) (<-chan LogLine, error) {
+	b.mu.Lock()
+	task, ok := b.tasks[taskID]
+	b.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("orchestrator: unknown task %q", taskID)
+	}
+	return task.logs.subscribe(ctx), nil
+}
+
+func (b *ExecBackend) Cancel(ctx context.Context, taskID string) error {
+	b.mu.Lock()
+	task, ok := b.tasks[taskID]
+	b.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("orchestrator: unknown task %q", taskID)
+	}
+	if task.cmd.Process == nil {
+		return fmt.Errorf("orchestrator: task %q has not started", taskID)
+	}
+	return task.cmd.Process.Kill()
+}
@@ -0,0 +1,54 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecBackend_RunsToCompletion(t *testing.T) {
+	b := NewExecBackend()
+
+	err := b.Submit(context.Background(), TaskDefinition{
+		ID:      "t1",
+		Command: "true",
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		status, err := b.Status(context.Background(), "t1")
+		return err == nil && status.State == TaskComplete
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestExecBackend_CompletesWithoutLogConsumer(t *testing.T) {
+	b := NewExecBackend()
+
+	// Writes far more lines than the old bounded channel's buffer
+	// could hold, with nothing ever calling Logs to drain it.
+	err := b.Submit(context.Background(), TaskDefinition{
+		ID:      "t2",
+		Command: "seq",
+		Args:    []string{"1", "5000"},
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		status, err := b.Status(context.Background(), "t2")
+		return err == nil && status.State == TaskComplete
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestExecBackend_UnknownTask(t *testing.T) {
+	b := NewExecBackend()
+
+	_, err := b.Status(context.Background(), "nope")
+	require.Error(t, err)
+}
+
+func TestNewBackend_UnknownKind(t *testing.T) {
+	_, err := NewBackend(BackendConfig{Kind: "bogus"})
+	require.Error(t, err)
+}
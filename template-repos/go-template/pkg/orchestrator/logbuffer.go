@@ -0,0 +1,88 @@
+package orchestrator
+
+import (
+	"context"
+	"sync"
+)
+
+// logBuffer accumulates LogLines from a running task so producers
+// (streamLines) never block on a slow or absent consumer. push only
+// ever appends to an in-memory slice; subscribe replays that history
+// and then tails new lines, each subscriber getting its own goroutine
+// so one slow subscriber can't stall another or the producer.
+type logBuffer struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	lines  []LogLine
+	closed bool
+}
+
+func newLogBuffer() *logBuffer {
+	b := &logBuffer{}
+	b.cond = sync.NewCond(&b.mu)
+	return b
+}
+
+// push appends line. It never blocks, regardless of whether anything
+// is subscribed.
+func (b *logBuffer) push(line LogLine) {
+	b.mu.Lock()
+	b.lines = append(b.lines, line)
+	b.mu.Unlock()
+	b.cond.Broadcast()
+}
+
+// closeBuffer marks the buffer done; subscribers drain whatever's left
+// and then close their channel.
+func (b *logBuffer) closeBuffer() {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+	b.cond.Broadcast()
+}
+
+// subscribe returns a channel replaying everything pushed so far,
+// followed by anything pushed afterward, until the buffer is closed
+// and fully drained or ctx is done.
+func (b *logBuffer) subscribe(ctx context.Context) <-chan LogLine {
+	out := make(chan LogLine, 64)
+
+	go func() {
+		defer close(out)
+
+		// Wake the condvar wait on ctx cancellation too, so a caller
+		// that abandons Logs() doesn't leak this goroutine forever.
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			select {
+			case <-ctx.Done():
+				b.cond.Broadcast()
+			case <-done:
+			}
+		}()
+
+		idx := 0
+		for {
+			b.mu.Lock()
+			for idx >= len(b.lines) && !b.closed && ctx.Err() == nil {
+				b.cond.Wait()
+			}
+			if idx >= len(b.lines) && (b.closed || ctx.Err() != nil) {
+				b.mu.Unlock()
+				return
+			}
+			line := b.lines[idx]
+			idx++
+			b.mu.Unlock()
+
+			select {
+			case out <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
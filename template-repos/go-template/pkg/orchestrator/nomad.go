@@ -0,0 +1,202 @@
+package orchestrator
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"text/template"
+
+	nomadapi "github.com/hashicorp/nomad/api"
+)
+
+// NomadBackend submits automation tasks as short-lived Nomad batch
+// jobs, rendering jobSpecTemplate per task and streaming logs back via
+// the allocation API.
+type NomadBackend struct {
+	nomad           *nomadapi.Client
+	jobSpecTemplate *template.Template
+}
+
+// jobSpecSource is the default batch-job template: one "run" task per
+// submitted TaskDefinition, with the command and environment rendered
+// in. Operators can swap this for their own via NewNomadBackendWithTemplate.
+const jobSpecSource = `
+job "automation-{{.ID}}" {
+  type = "batch"
+
+  group "run" {
+    task "run" {
+      driver = "raw_exec"
+
+      config {
+        command = "{{.Command}}"
+        args    = [{{range $i, $a := .Args}}{{if $i}}, {{end}}"{{$a}}"{{end}}]
+      }
+
+      env {
+        {{range $k, $v := .Env}}{{$k}} = "{{$v}}"
+        {{end}}
+      }
+    }
+  }
+}
+`
+
+// NewNomadBackend builds a backend that talks to the Nomad cluster
+// described by cfg.
+func NewNomadBackend(cfg *nomadapi.Config) (*NomadBackend, error) {
+	return NewNomadBackendWithTemplate(cfg, jobSpecSource)
+}
+
+// NewNomadBackendWithTemplate is like NewNomadBackend but lets callers
+// override the job-spec template.
+func NewNomadBackendWithTemplate(cfg *nomadapi.Config, tmplSource string) (*NomadBackend, error) {
+	client, err := nomadapi.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("orchestrator: build nomad client: %w", err)
+	}
+
+	tmpl, err := template.New("job-spec").Parse(tmplSource)
+	if err != nil {
+		return nil, fmt.Errorf("orchestrator: parse job-spec template: %w", err)
+	}
+
+	return &NomadBackend{nomad: client, jobSpecTemplate: tmpl}, nil
+}
+
+func (b *NomadBackend) Submit(ctx context.Context, def TaskDefinition) error {
+	var buf bytes.Buffer
+	if err := b.jobSpecTemplate.Execute(&buf, def); err != nil {
+		return fmt.Errorf("orchestrator: render job spec for %s: %w", def.ID, err)
+	}
+
+	job, err := b.nomad.Jobs().ParseHCL(buf.String(), true)
+	if err != nil {
+		return fmt.Errorf("orchestrator: parse rendered job spec for %s: %w", def.ID, err)
+	}
+
+	_, _, err = b.nomad.Jobs().Register(job, &nomadapi.WriteOptions{})
+	if err != nil {
+		return fmt.Errorf("orchestrator: register job for %s: %w", def.ID, err)
+	}
+
+	return nil
+}
+
+func (b *NomadBackend) Status(ctx context.Context, taskID string) (TaskStatus, error) {
+	allocs, _, err := b.nomad.Jobs().Allocations(jobName(taskID), false, &nomadapi.QueryOptions{})
+	if err != nil {
+		return TaskStatus{}, fmt.Errorf("orchestrator: list allocations for %s: %w", taskID, err)
+	}
+	if len(allocs) == 0 {
+		return TaskStatus{ID: taskID, State: TaskPending}, nil
+	}
+
+	return TaskStatus{ID: taskID, State: mapAllocState(allocs[0].ClientStatus)}, nil
+}
+
+func (b *NomadBackend) Logs(ctx context.Context, taskID string) (<-chan LogLine, error) {
+	allocs, _, err := b.nomad.Jobs().Allocations(jobName(taskID), false, &nomadapi.QueryOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("orchestrator: list allocations for %s: %w", taskID, err)
+	}
+	if len(allocs) == 0 {
+		return nil, fmt.Errorf("orchestrator: no allocation yet for %s", taskID)
+	}
+
+	alloc, _, err := b.nomad.Allocations().Info(allocs[0].ID, &nomadapi.QueryOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("orchestrator: fetch allocation %s: %w", allocs[0].ID, err)
+	}
+
+	out := make(chan LogLine, 64)
+
+	var wg sync.WaitGroup
+	for _, stream := range []string{"stdout", "stderr"} {
+		frames, errs := b.nomad.AllocFS().Logs(alloc, true, "run", stream, "start", 0, ctx.Done(), &nomadapi.QueryOptions{})
+		wg.Add(1)
+		go func(stream string) {
+			defer wg.Done()
+			pipeFrames(ctx, taskID, stream, frames, errs, out)
+		}(stream)
+	}
+
+	// Close out exactly once, after both stdout and stderr have
+	// finished, instead of each goroutine closing (and potentially
+	// still sending on) the shared channel.
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out, nil
+}
+
+// pipeFrames relays frames/errs onto out until they're exhausted or ctx
+// is done. Every send to out is guarded by ctx.Done() too: if a Logs
+// consumer stops reading and out's 64-slot buffer fills, this goroutine
+// must still be able to exit on cancellation instead of blocking
+// forever on out<- and leaving wg.Wait() (and out's close) stuck.
+func pipeFrames(ctx context.Context, taskID, stream string, frames <-chan *nomadapi.StreamFrame, errs <-chan error, out chan<- LogLine) {
+	for {
+		select {
+		case frame, ok := <-frames:
+			if !ok {
+				return
+			}
+			scanner := bufio.NewScanner(bytes.NewReader(frame.Data))
+			for scanner.Scan() {
+				line := LogLine{TaskID: taskID, Stream: stream, Line: scanner.Text()}
+				select {
+				case out <- line:
+				case <-ctx.Done():
+					return
+				}
+			}
+		case err, ok := <-errs:
+			if ok && err != nil {
+				line := LogLine{TaskID: taskID, Stream: stream, Line: "error: " + err.Error()}
+				select {
+				case out <- line:
+				case <-ctx.Done():
+				}
+			}
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (b *NomadBackend) Cancel(ctx context.Context, taskID string) error {
+	_, _, err := b.nomad.Jobs().Deregister(jobName(taskID), true, &nomadapi.WriteOptions{})
+	if err != nil {
+		return fmt.Errorf("orchestrator: deregister job for %s: %w", taskID, err)
+	}
+	return nil
+}
+
+func jobName(taskID string) string {
+	return "automation-" + taskID
+}
+
+// mapAllocState translates a Nomad allocation client status into our
+// cross-backend TaskState.
+func mapAllocState(clientStatus string) TaskState {
+	switch clientStatus {
+	case "pending":
+		return TaskPending
+	case "running":
+		return TaskRunning
+	case "complete":
+		return TaskComplete
+	case "failed":
+		return TaskFailed
+	case "lost":
+		return TaskFailed
+	default:
+		return TaskPending
+	}
+}
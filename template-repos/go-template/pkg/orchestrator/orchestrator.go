@@ -0,0 +1,66 @@
+// Package orchestrator abstracts how automation tasks are dispatched
+// and tracked, so the controller can run them as local subprocesses or
+// hand them off to a Nomad cluster without the rest of the codebase
+// caring which.
+package orchestrator
+
+import "context"
+
+// TaskState mirrors the lifecycle of a dispatched task across
+// backends.
+type TaskState string
+
+const (
+	TaskPending   TaskState = "pending"
+	TaskRunning   TaskState = "running"
+	TaskComplete  TaskState = "complete"
+	TaskFailed    TaskState = "failed"
+	TaskCancelled TaskState = "cancelled"
+)
+
+// TaskDefinition describes a unit of work to dispatch: the command to
+// run and the environment it needs. Backends translate this into
+// whatever native job spec they require.
+type TaskDefinition struct {
+	ID      string
+	Command string
+	Args    []string
+	Env     map[string]string
+}
+
+// TaskStatus reports the current state of a dispatched task.
+type TaskStatus struct {
+	ID    string
+	State TaskState
+	Err   string
+}
+
+// LogLine is one line of stdout/stderr streamed back from a running
+// task.
+type LogLine struct {
+	TaskID string
+	Stream string // "stdout" or "stderr"
+	Line   string
+}
+
+// Backend dispatches and tracks automation tasks. The "exec" backend
+// runs tasks as local subprocesses, preserving today's behavior; the
+// "nomad" backend submits them as Nomad batch jobs.
+type Backend interface {
+	// Submit dispatches def and returns immediately; use Status and
+	// Logs to observe progress.
+	Submit(ctx context.Context, def TaskDefinition) error
+
+	// Status returns the current state of a previously submitted task.
+	Status(ctx context.Context, taskID string) (TaskStatus, error)
+
+	// Logs streams log lines for taskID until ctx is done or the task
+	// finishes, whichever comes first.
+	Logs(ctx context.Context, taskID string) (<-chan LogLine, error)
+
+	// Cancel requests that a running task stop.
+	Cancel(ctx context.Context, taskID string) error
+}
+
+// Selector picks a Backend by name, as configured at startup.
+type Selector func(name string) (Backend, error)
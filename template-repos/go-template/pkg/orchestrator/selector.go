@@ -0,0 +1,45 @@
+package orchestrator
+
+import (
+	"fmt"
+
+	consulapi "github.com/hashicorp/consul/api"
+	nomadapi "github.com/hashicorp/nomad/api"
+)
+
+// BackendConfig selects and configures an orchestrator Backend at
+// startup.
+type BackendConfig struct {
+	// Kind is "exec" (default, runs tasks as local subprocesses) or
+	// "nomad" (submits tasks as Nomad batch jobs).
+	Kind string
+
+	NomadAddr  string
+	ConsulAddr string
+}
+
+// NewBackend builds the Backend selected by cfg.Kind.
+func NewBackend(cfg BackendConfig) (Backend, error) {
+	switch cfg.Kind {
+	case "", "exec":
+		return NewExecBackend(), nil
+	case "nomad":
+		nomadCfg := nomadapi.DefaultConfig()
+		if cfg.NomadAddr != "" {
+			nomadCfg.Address = cfg.NomadAddr
+		}
+		return NewNomadBackend(nomadCfg)
+	default:
+		return nil, fmt.Errorf("orchestrator: unknown backend kind %q", cfg.Kind)
+	}
+}
+
+// NewConsulClient builds a Consul API client for service registration
+// and KV watching, used alongside (not as part of) the task backend.
+func NewConsulClient(addr string) (*consulapi.Client, error) {
+	cfg := consulapi.DefaultConfig()
+	if addr != "" {
+		cfg.Address = addr
+	}
+	return consulapi.NewClient(cfg)
+}
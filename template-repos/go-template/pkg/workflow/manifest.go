@@ -0,0 +1,60 @@
+// Package workflow holds the automation controller's workflow
+// manifests: the parsed definitions that say what a workflow's steps
+// are, independent of any one run of it.
+package workflow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/your-org/project-name/pkg/cache"
+)
+
+// Manifest is a parsed workflow definition.
+type Manifest struct {
+	ID    string
+	Steps []string
+}
+
+// Store loads a manifest by ID from wherever manifests are actually
+// persisted (e.g. a git-backed repo or object store).
+type Store interface {
+	Load(ctx context.Context, id string) (Manifest, error)
+}
+
+// CachedStore wraps a Store with a cache of parsed manifests, since
+// parsing and fetching one on every workflow dispatch is pure
+// overhead — manifests change far less often than they're read.
+type CachedStore struct {
+	store Store
+	cache *cache.Cache[string, Manifest]
+}
+
+// NewCachedStore builds a CachedStore backed by store.
+func NewCachedStore(store Store) (*CachedStore, error) {
+	c, err := cache.New(cache.Options[string, Manifest]{
+		Name:    "workflow.manifests",
+		MaxCost: 50000,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("workflow: build manifest cache: %w", err)
+	}
+	return &CachedStore{store: store, cache: c}, nil
+}
+
+// Load returns the manifest for id, serving from cache when possible.
+func (s *CachedStore) Load(ctx context.Context, id string) (Manifest, error) {
+	return s.cache.GetOrLoad(ctx, id, func(ctx context.Context, id string) (Manifest, int64, error) {
+		m, err := s.store.Load(ctx, id)
+		if err != nil {
+			return Manifest{}, 0, err
+		}
+		return m, int64(len(m.Steps)) + 1, nil
+	})
+}
+
+// Invalidate evicts id from the cache, e.g. after the manifest is
+// edited, so the next Load re-fetches it.
+func (s *CachedStore) Invalidate(id string) {
+	s.cache.Delete(id)
+}